@@ -0,0 +1,244 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// BalancePolicy selects which healthy connection a [Balancer] uses for the
+// next invocation.
+type BalancePolicy int
+
+const (
+	// RoundRobin cycles through healthy connections in order.
+	RoundRobin BalancePolicy = iota
+	// Random picks a healthy connection uniformly at random.
+	Random
+	// LowestRTT picks the healthy connection with the lowest last-probed
+	// round-trip time.
+	LowestRTT
+)
+
+// BalancerOption configures a [Balancer] constructed by [NewBalancedClient].
+type BalancerOption func(*Balancer)
+
+// WithBalancePolicy sets the policy used to pick a healthy connection for
+// each invocation. The default is [RoundRobin].
+func WithBalancePolicy(p BalancePolicy) BalancerOption {
+	return func(b *Balancer) { b.policy = p }
+}
+
+// WithMaxAttempts bounds how many connections a single invocation tries
+// before giving up and returning the last error. The default is 1, i.e. no
+// retry across connections. Values less than 1 are treated as 1, since a
+// non-positive attempt count would make Invoke return successfully without
+// ever contacting a connection.
+func WithMaxAttempts(n int) BalancerOption {
+	return func(b *Balancer) {
+		if n < 1 {
+			n = 1
+		}
+		b.maxAttempts = n
+	}
+}
+
+// WithRTTThreshold marks a connection unhealthy when its probed RTT exceeds
+// d. The default, zero, disables RTT-based health checks.
+func WithRTTThreshold(d time.Duration) BalancerOption {
+	return func(b *Balancer) { b.rttThreshold = d }
+}
+
+// WithBalancerPrefix sets the subject prefix used by every underlying
+// [Client], equivalent to [WithPrefix] on a single-connection Client.
+func WithBalancerPrefix(prefix string) BalancerOption {
+	return func(b *Balancer) { b.prefix = prefix }
+}
+
+// Balancer is a [wrpc.Invoker] that spreads invocations across multiple
+// NATS connections - potentially to different NATS superclusters - tracking
+// per-connection health so a failing connection is transparently routed
+// around.
+//
+// Each attempt returns a call's result bytes whole, once the reply has
+// arrived; this package has no streamed invocation results, so a retry on
+// another connection never repeats output already handed to the caller.
+type Balancer struct {
+	conns        []*connState
+	policy       BalancePolicy
+	maxAttempts  int
+	rttThreshold time.Duration
+	prefix       string
+
+	rr atomic.Uint64
+}
+
+type connState struct {
+	client *Client
+
+	mu        sync.Mutex
+	healthy   bool
+	backoff   time.Duration
+	recheckAt time.Time
+	rtt       time.Duration
+}
+
+const (
+	minBackoff = 100 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// NewBalancedClient constructs a Balancer spreading invocations across
+// conns. Every connection starts out healthy.
+func NewBalancedClient(conns []*nats.Conn, opts ...BalancerOption) *Balancer {
+	b := &Balancer{maxAttempts: 1}
+	for _, opt := range opts {
+		opt(b)
+	}
+	for _, nc := range conns {
+		b.conns = append(b.conns, &connState{
+			client:  NewClient(nc, WithPrefix(b.prefix)),
+			healthy: true,
+			backoff: minBackoff,
+		})
+	}
+	return b
+}
+
+// Invoke implements [wrpc.Invoker], selecting a healthy connection per
+// [BalancePolicy] and retrying on the next healthy connection, up to
+// MaxAttempts, if the attempt fails with a connection-level error.
+func (b *Balancer) Invoke(ctx context.Context, instance, name string, params []byte) ([]byte, error) {
+	var lastErr error
+	tried := make(map[*connState]struct{}, len(b.conns))
+
+	for attempt := 0; attempt < b.maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		cs := b.pick(tried)
+		if cs == nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, errors.New("wrpcnats: no healthy connection available")
+		}
+		tried[cs] = struct{}{}
+
+		result, err := cs.client.Invoke(ctx, instance, name, params)
+		if err == nil {
+			// probeRTT does a real network round trip via nc.RTT(), so only
+			// pay for it when the result actually informs connection
+			// selection: LowestRTT ranks by it directly, and a positive
+			// rttThreshold uses it to mark a connection unhealthy. Otherwise
+			// the successful call alone marks the connection healthy again
+			// and resets its backoff.
+			if b.policy == LowestRTT || b.rttThreshold > 0 {
+				b.probeRTT(cs)
+			} else {
+				cs.mu.Lock()
+				cs.healthy = true
+				cs.backoff = minBackoff
+				cs.mu.Unlock()
+			}
+			return result, nil
+		}
+		lastErr = err
+		if isConnectionError(err) {
+			b.markUnhealthy(cs)
+			continue
+		}
+		return nil, err
+	}
+	return nil, lastErr
+}
+
+func isConnectionError(err error) bool {
+	return errors.Is(err, nats.ErrConnectionClosed) || errors.Is(err, nats.ErrNoResponders)
+}
+
+// pick returns a healthy connection not yet in tried, per b.policy, or nil
+// if none remain.
+func (b *Balancer) pick(tried map[*connState]struct{}) *connState {
+	var candidates []*connState
+	now := time.Now()
+	for _, cs := range b.conns {
+		if _, skip := tried[cs]; skip {
+			continue
+		}
+		cs.mu.Lock()
+		healthy := cs.healthy
+		recheckAt := cs.recheckAt
+		cs.mu.Unlock()
+		if !healthy && now.Before(recheckAt) {
+			continue
+		}
+		candidates = append(candidates, cs)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	switch b.policy {
+	case Random:
+		return candidates[rand.Intn(len(candidates))]
+	case LowestRTT:
+		best := candidates[0]
+		for _, cs := range candidates[1:] {
+			cs.mu.Lock()
+			betterRTT := cs.rtt < best.rtt
+			cs.mu.Unlock()
+			if betterRTT {
+				best = cs
+			}
+		}
+		return best
+	default: // RoundRobin
+		i := b.rr.Add(1) - 1
+		return candidates[i%uint64(len(candidates))]
+	}
+}
+
+// markUnhealthy marks cs unhealthy and schedules its next health recheck
+// with exponential backoff, so it is re-tried instead of permanently
+// excluded.
+func (b *Balancer) markUnhealthy(cs *connState) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.healthy = false
+	cs.recheckAt = time.Now().Add(cs.backoff)
+	cs.backoff *= 2
+	if cs.backoff > maxBackoff {
+		cs.backoff = maxBackoff
+	}
+}
+
+// probeRTT measures the connection's round-trip time with a NATS ping after
+// a successful invocation, marks the connection healthy again and resets its
+// backoff. If rttThreshold is set
+// and exceeded, the connection is marked unhealthy instead.
+func (b *Balancer) probeRTT(cs *connState) {
+	rtt, err := cs.client.nc.RTT()
+	if err != nil {
+		return
+	}
+
+	cs.mu.Lock()
+	cs.rtt = rtt
+	cs.healthy = true
+	cs.backoff = minBackoff
+	cs.mu.Unlock()
+
+	if b.rttThreshold > 0 && rtt > b.rttThreshold {
+		b.markUnhealthy(cs)
+	}
+}
@@ -0,0 +1,51 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+)
+
+func TestBalancerFailsOverOnConnectionError(t *testing.T) {
+	ncA := newTestConn(t)
+	ncB := newTestConn(t)
+
+	served := NewClient(ncB, WithPrefix("test"))
+	stop, err := Serve(served, Binding{
+		Instance: "inst",
+		Name:     "f",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			return []byte("ok"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Serve: %s", err)
+	}
+	defer stop()
+
+	// ncA is closed up front to simulate a connection that has already
+	// gone bad; the Balancer should route around it instead of failing
+	// the invocation.
+	ncA.Close()
+
+	b := NewBalancedClient([]*nats.Conn{ncA, ncB}, WithBalancerPrefix("test"), WithMaxAttempts(2))
+	result, err := b.Invoke(context.Background(), "inst", "f", nil)
+	if err != nil {
+		t.Fatalf("Invoke: %s", err)
+	}
+	if string(result) != "ok" {
+		t.Fatalf("expected %q, got %q", "ok", result)
+	}
+}
+
+func TestWithMaxAttemptsClampsNonPositive(t *testing.T) {
+	b := NewBalancedClient(nil, WithMaxAttempts(0))
+	if b.maxAttempts != 1 {
+		t.Fatalf("expected a non-positive MaxAttempts to be clamped to 1, got %d", b.maxAttempts)
+	}
+
+	if _, err := b.Invoke(context.Background(), "inst", "f", nil); err == nil {
+		t.Fatal("expected an error invoking with no connections configured, got nil")
+	}
+}
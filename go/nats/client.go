@@ -0,0 +1,120 @@
+// Package nats implements a wRPC transport over NATS.io.
+//
+// A [Client] is a [wrpc.Invoker] bound to a NATS connection; generated
+// bindings invoke functions through it and, for worlds that export
+// interfaces, serve them with [Serve].
+package nats
+
+import (
+	"context"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+// cancelSuffix is appended to a reply inbox subject to derive the subject a
+// client publishes on to tell the server-side handler for that invocation
+// to stop: the server subscribes to it for the lifetime of the handler and
+// cancels its context on any message.
+const cancelSuffix = ".cancel"
+
+// Client is a [wrpc.Invoker] backed by a NATS.io connection.
+type Client struct {
+	nc     *nats.Conn
+	prefix string
+
+	lameDuckTimeout    time.Duration
+	defaultCallTimeout time.Duration
+
+	interceptors []ClientInterceptor
+}
+
+// Option configures a [Client] constructed by [NewClient].
+type Option func(*Client)
+
+// WithPrefix sets the subject prefix used for every invocation and
+// subscription made through the client. The default is no prefix.
+func WithPrefix(prefix string) Option {
+	return func(c *Client) { c.prefix = prefix }
+}
+
+// WithLameDuckTimeout bounds how long [Service.Stop] (and the `stop` func
+// returned by [Serve] and generated `Serve` functions) waits for in-flight
+// invocations to finish draining before force-cancelling them. The
+// default, zero, cancels outstanding invocations immediately instead of
+// draining them.
+func WithLameDuckTimeout(d time.Duration) Option {
+	return func(c *Client) { c.lameDuckTimeout = d }
+}
+
+// WithDefaultCallTimeout bounds invocations whose context has no deadline,
+// so a caller that forgets to apply one still releases the client's NATS
+// subscription and buffered reply channel for that call instead of
+// blocking on it forever.
+func WithDefaultCallTimeout(d time.Duration) Option {
+	return func(c *Client) { c.defaultCallTimeout = d }
+}
+
+// NewClient constructs a Client bound to nc.
+func NewClient(nc *nats.Conn, opts ...Option) *Client {
+	c := &Client{nc: nc}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// subject returns the NATS subject a given instance/name invocation is
+// published and subscribed on.
+func (c *Client) subject(instance, name string) string {
+	if c.prefix == "" {
+		return instance + "." + name
+	}
+	return c.prefix + "." + instance + "." + name
+}
+
+// Invoke implements [wrpc.Invoker] as a NATS request on the instance/name
+// subject, returning the responding server's encoded result, after running
+// the call through any [ClientInterceptor]s installed by
+// [WithClientInterceptor].
+//
+// If ctx has no deadline, [WithDefaultCallTimeout] is applied to it, with
+// the resulting cancel func deferred so the invocation's NATS subscription
+// and buffered reply channel are always released. If ctx is cancelled
+// before a reply arrives, Invoke publishes a cancellation signal on the
+// invocation's reply inbox subject with [cancelSuffix] appended, so the
+// server-side handler spawned by [Serve]
+// observes ctx cancellation instead of running to completion unobserved.
+func (c *Client) Invoke(ctx context.Context, instance, name string, params []byte) ([]byte, error) {
+	return chainInvoke(c.interceptors, c.invoke)(ctx, instance, name, params)
+}
+
+func (c *Client) invoke(ctx context.Context, instance, name string, params []byte) ([]byte, error) {
+	if _, ok := ctx.Deadline(); !ok && c.defaultCallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.defaultCallTimeout)
+		defer cancel()
+	}
+
+	inbox := c.nc.NewInbox()
+	sub, err := c.nc.SubscribeSync(inbox)
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+
+	if err := c.nc.PublishRequest(c.subject(instance, name), inbox, params); err != nil {
+		return nil, err
+	}
+
+	msg, err := sub.NextMsgWithContext(ctx)
+	if err != nil {
+		// The caller's context is done (or timed out): tell the
+		// server-side handler to give up on this invocation instead of
+		// running to completion against a reply inbox nobody is
+		// listening on anymore.
+		_ = c.nc.Publish(inbox+cancelSuffix, nil)
+		return nil, err
+	}
+	return msg.Data, nil
+}
@@ -0,0 +1,82 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientDefaultCallTimeout(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"), WithDefaultCallTimeout(100*time.Millisecond))
+
+	release := make(chan struct{})
+	stop, err := Serve(client, Binding{
+		Instance: "inst",
+		Name:     "hang",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			<-release
+			return nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Serve: %s", err)
+	}
+
+	start := time.Now()
+	_, err = client.Invoke(context.Background(), "inst", "hang", nil)
+	elapsed := time.Since(start)
+
+	// Unblock the handler before stopping so stop's drain doesn't wait on
+	// it; Invoke timing out cancels the handler's ctx, but this handler
+	// ignores ctx and keeps waiting on release.
+	close(release)
+	if err := stop(); err != nil {
+		t.Fatalf("stop: %s", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+	if elapsed > time.Second {
+		t.Fatalf("default call timeout was not honored, took %s", elapsed)
+	}
+}
+
+func TestClientCancelPropagatesToHandler(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"))
+
+	handlerCanceled := make(chan struct{})
+	stop, err := Serve(client, Binding{
+		Instance: "inst",
+		Name:     "cancel",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			<-ctx.Done()
+			close(handlerCanceled)
+			return nil, ctx.Err()
+		},
+	})
+	if err != nil {
+		t.Fatalf("Serve: %s", err)
+	}
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	invokeDone := make(chan struct{})
+	go func() {
+		client.Invoke(ctx, "inst", "cancel", nil)
+		close(invokeDone)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-handlerCanceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("server-side handler did not observe client ctx cancellation")
+	}
+	<-invokeDone
+}
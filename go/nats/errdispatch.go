@@ -0,0 +1,54 @@
+package nats
+
+import (
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// errorDispatcher fans a *nats.Conn's single async error callback out to
+// whichever subscription it actually failed on. nats.go only lets a
+// connection have one [nats.Conn.SetErrorHandler] callback, but a process
+// composing several [Service]s onto one shared connection - the use case
+// this package's Service is for - needs each Service to observe only its
+// own subscriptions' errors, not have the last Service to call Start win.
+var errorDispatchers sync.Map // map[*nats.Conn]*errorDispatcher
+
+type errorDispatcher struct {
+	mu   sync.Mutex
+	subs map[*nats.Subscription]func(error)
+}
+
+// watchConnErrors returns the errorDispatcher for nc, installing it as the
+// connection's error handler the first time it's requested.
+func watchConnErrors(nc *nats.Conn) *errorDispatcher {
+	v, loaded := errorDispatchers.LoadOrStore(nc, &errorDispatcher{subs: make(map[*nats.Subscription]func(error))})
+	d := v.(*errorDispatcher)
+	if !loaded {
+		nc.SetErrorHandler(func(_ *nats.Conn, sub *nats.Subscription, err error) {
+			d.mu.Lock()
+			fail := d.subs[sub]
+			d.mu.Unlock()
+			if fail != nil {
+				fail(err)
+			}
+		})
+	}
+	return d
+}
+
+// watch registers fail to be called with a subscription's terminal error.
+func (d *errorDispatcher) watch(sub *nats.Subscription, fail func(error)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subs[sub] = fail
+}
+
+// forget stops watching subs, e.g. once they're unsubscribed.
+func (d *errorDispatcher) forget(subs []*nats.Subscription) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, sub := range subs {
+		delete(d.subs, sub)
+	}
+}
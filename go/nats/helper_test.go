@@ -0,0 +1,37 @@
+package nats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	natsserver "github.com/nats-io/nats-server/v2/server"
+)
+
+// newTestConn starts an embedded, ephemeral NATS server and returns a
+// connection to it, both torn down via t.Cleanup.
+func newTestConn(t *testing.T) *nats.Conn {
+	t.Helper()
+
+	srv, err := natsserver.NewServer(&natsserver.Options{
+		Host:   "127.0.0.1",
+		Port:   -1,
+		NoLog:  true,
+		NoSigs: true,
+	})
+	if err != nil {
+		t.Fatalf("failed to start NATS.io server: %s", err)
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(5 * time.Second) {
+		t.Fatal("NATS.io server did not become ready")
+	}
+	t.Cleanup(srv.Shutdown)
+
+	nc, err := nats.Connect(srv.ClientURL())
+	if err != nil {
+		t.Fatalf("failed to connect to NATS.io: %s", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
@@ -0,0 +1,65 @@
+package nats
+
+import "context"
+
+// Invoke performs (or continues) an outgoing invocation. It is the shape of
+// both [Client.Invoke] and the `next` func passed to a [ClientInterceptor].
+type Invoke func(ctx context.Context, instance, name string, params []byte) ([]byte, error)
+
+// ClientInterceptor wraps an outgoing invocation, analogous to a gRPC
+// unary client interceptor. Implementations may inspect or modify ctx and
+// params before calling next, and inspect the result bytes and error it
+// returns; this unlocks cross-cutting concerns - tracing, auth token
+// attachment, retries, metrics - without touching generated bindings.
+//
+// An interceptor only sees a call's initial result bytes, not any
+// subsequent streamed output written after Invoke returns; this package
+// does not yet support streamed invocation results.
+type ClientInterceptor func(ctx context.Context, instance, name string, params []byte, next Invoke) ([]byte, error)
+
+// WithClientInterceptor appends interceptors to the client's invocation
+// chain. They run in the order given: the first interceptor is outermost
+// and sees the call before the others do.
+func WithClientInterceptor(interceptors ...ClientInterceptor) Option {
+	return func(c *Client) { c.interceptors = append(c.interceptors, interceptors...) }
+}
+
+func chainInvoke(interceptors []ClientInterceptor, final Invoke) Invoke {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		next, ic := final, interceptors[i]
+		final = func(ctx context.Context, instance, name string, params []byte) ([]byte, error) {
+			return ic(ctx, instance, name, params, next)
+		}
+	}
+	return final
+}
+
+// ServerInterceptor wraps an incoming invocation's dispatch to its
+// [Handler], analogous to a gRPC unary server interceptor. Implementations
+// may inspect or modify ctx and params before calling next, and inspect the
+// result bytes and error it returns; this unlocks cross-cutting concerns -
+// trace context extraction, auth token verification, structured request
+// logging, metrics - without touching generated bindings.
+//
+// As with [ClientInterceptor], an interceptor only sees a call's initial
+// result bytes, not any subsequent streamed output.
+type ServerInterceptor func(ctx context.Context, instance, name string, params []byte, next Handler) ([]byte, error)
+
+// ServeOption configures a [Service] constructed by [NewService].
+type ServeOption func(*Service)
+
+// WithServerInterceptor appends interceptors to the service's dispatch
+// chain, in the order given, analogous to [WithClientInterceptor].
+func WithServerInterceptor(interceptors ...ServerInterceptor) ServeOption {
+	return func(s *Service) { s.interceptors = append(s.interceptors, interceptors...) }
+}
+
+func chainHandler(interceptors []ServerInterceptor, instance, name string, final Handler) Handler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		next, ic := final, interceptors[i]
+		final = func(ctx context.Context, params []byte) ([]byte, error) {
+			return ic(ctx, instance, name, params, next)
+		}
+	}
+	return final
+}
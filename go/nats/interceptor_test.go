@@ -0,0 +1,79 @@
+package nats
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestClientInterceptorOrder(t *testing.T) {
+	nc := newTestConn(t)
+
+	var order []string
+	wrap := func(name string) ClientInterceptor {
+		return func(ctx context.Context, instance, fn string, params []byte, next Invoke) ([]byte, error) {
+			order = append(order, name+":before")
+			result, err := next(ctx, instance, fn, params)
+			order = append(order, name+":after")
+			return result, err
+		}
+	}
+
+	client := NewClient(nc, WithPrefix("test"), WithClientInterceptor(wrap("a"), wrap("b")))
+	stop, err := Serve(client, Binding{
+		Instance: "inst",
+		Name:     "f",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			return []byte("ok"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Serve: %s", err)
+	}
+	defer stop()
+
+	if _, err := client.Invoke(context.Background(), "inst", "f", nil); err != nil {
+		t.Fatalf("Invoke: %s", err)
+	}
+
+	expected := []string{"a:before", "b:before", "b:after", "a:after"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+}
+
+func TestServerInterceptorOrder(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"))
+
+	var order []string
+	wrap := func(name string) ServerInterceptor {
+		return func(ctx context.Context, instance, fn string, params []byte, next Handler) ([]byte, error) {
+			order = append(order, name+":before")
+			result, err := next(ctx, params)
+			order = append(order, name+":after")
+			return result, err
+		}
+	}
+
+	svc := NewService(client, []Binding{{
+		Instance: "inst",
+		Name:     "f",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			return []byte("ok"), nil
+		},
+	}}, WithServerInterceptor(wrap("a"), wrap("b")))
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %s", err)
+	}
+	defer svc.Stop(context.Background())
+
+	if _, err := client.Invoke(context.Background(), "inst", "f", nil); err != nil {
+		t.Fatalf("Invoke: %s", err)
+	}
+
+	expected := []string{"a:before", "b:before", "b:after", "a:after"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+}
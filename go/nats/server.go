@@ -0,0 +1,121 @@
+package nats
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Handler serves a single invocation of instance.name, given its raw
+// parameter bytes, and returns the raw result bytes to reply with.
+type Handler func(ctx context.Context, params []byte) ([]byte, error)
+
+// Binding pairs a served instance.name with the Handler that handles it.
+// Generated `Serve` functions build one Binding per exported function.
+type Binding struct {
+	Instance string
+	Name     string
+	Handler  Handler
+}
+
+// Server tracks the NATS subscriptions and in-flight invocations for a
+// served wRPC world. [Service] owns a Server for the lifetime of one
+// Start/Stop cycle.
+type Server struct {
+	client *Client
+	subs   []*nats.Subscription
+	wg     sync.WaitGroup
+
+	draining atomic.Bool
+
+	// drainCtx is the parent of every in-flight handler's ctx; cancelling
+	// it force-cancels every handler still running once StopContext's
+	// grace period elapses.
+	drainCtx    context.Context
+	drainCancel context.CancelFunc
+}
+
+// newServer constructs a Server ready to dispatch invocations for client.
+func newServer(client *Client) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Server{client: client, drainCtx: ctx, drainCancel: cancel}
+}
+
+func (s *Server) dispatch(b Binding) nats.MsgHandler {
+	return func(msg *nats.Msg) {
+		s.wg.Add(1)
+		defer s.wg.Done()
+
+		ctx, cancel := context.WithCancel(s.drainCtx)
+		defer cancel()
+		if msg.Reply != "" {
+			cancelSub, err := s.client.nc.Subscribe(msg.Reply+cancelSuffix, func(*nats.Msg) {
+				cancel()
+			})
+			if err == nil {
+				defer cancelSub.Unsubscribe()
+			}
+		}
+
+		result, err := b.Handler(ctx, msg.Data)
+		if err != nil {
+			// A non-nil error here means the invocation couldn't be
+			// dispatched at all; application-level failures are encoded
+			// into result by the handler itself, so there's nothing to
+			// reply with.
+			return
+		}
+		if msg.Reply != "" {
+			_ = msg.Respond(result)
+		}
+	}
+}
+
+func (s *Server) unsubscribeAll() {
+	for _, sub := range s.subs {
+		_ = sub.Unsubscribe()
+	}
+}
+
+// ForceCancel cancels every in-flight handler's ctx immediately, without
+// waiting for StopContext's grace period to elapse. [Service.Stop] calls it
+// for the default, zero, [WithLameDuckTimeout] instead of giving handlers
+// any time to drain.
+func (s *Server) ForceCancel() {
+	s.drainCancel()
+}
+
+// Draining reports whether the server has stopped accepting new
+// invocations and is waiting for in-flight ones to finish, so operators can
+// exclude it from load balancing before it goes away entirely.
+func (s *Server) Draining() bool {
+	return s.draining.Load()
+}
+
+// StopContext unsubscribes every request subject immediately, so no new
+// invocations are accepted, then waits for in-flight handler goroutines to
+// return, up to ctx's deadline. Only the handler calls themselves are
+// tracked; anything a handler leaves running after it returns is not
+// waited for. If ctx is done before every handler finishes, StopContext
+// cancels the drainCtx every handler's ctx was derived from - force-
+// cancelling the rest - and returns ctx.Err().
+func (s *Server) StopContext(ctx context.Context) error {
+	s.draining.Store(true)
+	s.unsubscribeAll()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.drainCancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,103 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestServerDrainsInFlightHandlerBeforeDeadline(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"))
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	svc := NewService(client, []Binding{{
+		Instance: "inst",
+		Name:     "slow",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			close(started)
+			<-release
+			return []byte("done"), nil
+		},
+	}})
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %s", err)
+	}
+
+	invokeDone := make(chan error, 1)
+	go func() {
+		_, err := client.Invoke(context.Background(), "inst", "slow", nil)
+		invokeDone <- err
+	}()
+	<-started
+
+	if svc.srv.Draining() {
+		t.Fatal("expected Draining to be false before StopContext is called")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	stopDone := make(chan error, 1)
+	go func() { stopDone <- svc.srv.StopContext(ctx) }()
+
+	select {
+	case <-stopDone:
+		t.Fatal("StopContext returned before the in-flight handler finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+	if !svc.srv.Draining() {
+		t.Fatal("expected Draining to be true while StopContext waits for the in-flight handler")
+	}
+
+	close(release)
+
+	select {
+	case err := <-stopDone:
+		if err != nil {
+			t.Fatalf("StopContext: %s", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("StopContext did not return after the in-flight handler finished")
+	}
+	if err := <-invokeDone; err != nil {
+		t.Fatalf("Invoke: %s", err)
+	}
+}
+
+func TestServerForceCancelsHandlersAfterDrainDeadline(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"))
+
+	started := make(chan struct{})
+	handlerCanceled := make(chan struct{})
+	svc := NewService(client, []Binding{{
+		Instance: "inst",
+		Name:     "stuck",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			close(started)
+			<-ctx.Done()
+			close(handlerCanceled)
+			return nil, ctx.Err()
+		},
+	}})
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %s", err)
+	}
+
+	go client.Invoke(context.Background(), "inst", "stuck", nil)
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := svc.srv.StopContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+
+	select {
+	case <-handlerCanceled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("handler was not force-cancelled once the drain deadline elapsed")
+	}
+}
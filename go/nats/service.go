@@ -0,0 +1,168 @@
+package nats
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"wrpc.io/go"
+)
+
+// Service is a [wrpc.Service] for a single served wRPC world over NATS.
+// Generated `NewService` constructors (e.g. `sync_server.NewService`) build
+// one via [NewService]; [Serve] remains a thin wrapper around it for
+// callers that only need the historical `(stop func() error, err error)`
+// shape.
+type Service struct {
+	client   *Client
+	bindings []Binding
+
+	interceptors []ServerInterceptor
+
+	srv *Server
+
+	readyOnce sync.Once
+	ready     chan struct{}
+
+	stopOnce sync.Once
+	stopped  chan struct{}
+
+	errOnce sync.Once
+	errCh   chan struct{}
+	err     error
+}
+
+var _ wrpc.Service = (*Service)(nil)
+
+// NewService constructs a Service for the given bindings. It does not
+// subscribe anything until [Service.Start] is called.
+func NewService(client *Client, bindings []Binding, opts ...ServeOption) *Service {
+	s := &Service{
+		client:   client,
+		bindings: bindings,
+		ready:    make(chan struct{}),
+		stopped:  make(chan struct{}),
+		errCh:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start subscribes the request subject for every binding, then closes
+// [Service.Ready] once every subscription is in place and flushed.
+//
+// Start watches the underlying NATS connection for terminal subscription
+// failures (e.g. a permission error or slow-consumer drop) on this Service's
+// own subscriptions and surfaces them from [Service.Wait]. The connection's
+// error handler is shared: multiple Services on the same *nats.Conn (e.g.
+// several served worlds in one process) can each call Start without
+// clobbering one another's error reporting.
+func (s *Service) Start(ctx context.Context) error {
+	srv := newServer(s.client)
+	dispatcher := watchConnErrors(s.client.nc)
+
+	for _, b := range s.bindings {
+		b.Handler = chainHandler(s.interceptors, b.Instance, b.Name, b.Handler)
+		subj := s.client.subject(b.Instance, b.Name)
+		sub, err := s.client.nc.Subscribe(subj, srv.dispatch(b))
+		if err != nil {
+			dispatcher.forget(srv.subs)
+			srv.unsubscribeAll()
+			return err
+		}
+		srv.subs = append(srv.subs, sub)
+		dispatcher.watch(sub, s.fail)
+	}
+	// nats.Conn.FlushWithContext rejects a context with no deadline, but
+	// Start's contract (and Serve, which calls it with
+	// context.Background()) doesn't require callers to set one, so only
+	// use ctx for the flush when it actually carries a deadline.
+	var flushErr error
+	if _, ok := ctx.Deadline(); ok {
+		flushErr = s.client.nc.FlushWithContext(ctx)
+	} else {
+		flushErr = s.client.nc.Flush()
+	}
+	if flushErr != nil {
+		dispatcher.forget(srv.subs)
+		srv.unsubscribeAll()
+		return flushErr
+	}
+
+	s.srv = srv
+	s.readyOnce.Do(func() { close(s.ready) })
+	return nil
+}
+
+// Stop performs the graceful drain documented on [Server.StopContext]. If
+// ctx has no deadline, the client's [WithLameDuckTimeout] is applied to it;
+// with the default, zero, timeout, outstanding invocations are cancelled
+// immediately instead of drained. It returns an error without blocking if
+// [Service.Start] has not completed successfully.
+func (s *Service) Stop(ctx context.Context) error {
+	if s.srv == nil {
+		return errors.New("wrpcnats: service not started")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		if d := s.client.lameDuckTimeout; d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		} else {
+			s.srv.ForceCancel()
+		}
+	}
+	err := s.srv.StopContext(ctx)
+	watchConnErrors(s.client.nc).forget(s.srv.subs)
+	s.stopOnce.Do(func() { close(s.stopped) })
+	return err
+}
+
+// Wait blocks until a subscription fails terminally, returning that error,
+// or until Stop completes, returning nil.
+func (s *Service) Wait() error {
+	select {
+	case <-s.errCh:
+		return s.err
+	case <-s.stopped:
+		return nil
+	}
+}
+
+// Ready closes once every subject named by the world is subscribed and
+// flushed.
+func (s *Service) Ready() <-chan struct{} {
+	return s.ready
+}
+
+// Err returns the terminal subscription error observed by Wait, if any has
+// occurred yet, without blocking.
+func (s *Service) Err() error {
+	select {
+	case <-s.errCh:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+func (s *Service) fail(err error) {
+	s.errOnce.Do(func() {
+		s.err = err
+		close(s.errCh)
+	})
+}
+
+// Serve starts a [Service] for the given bindings and returns a stop func
+// matching the historical `(stop func() error, err error)` shape generated
+// `Serve` functions expose, for callers that don't need the full [Service]
+// lifecycle or interceptors; use [NewService] directly for those.
+func Serve(client *Client, bindings ...Binding) (stop func() error, err error) {
+	svc := NewService(client, bindings)
+	if err := svc.Start(context.Background()); err != nil {
+		return nil, err
+	}
+	return func() error { return svc.Stop(context.Background()) }, nil
+}
@@ -0,0 +1,62 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestServiceStartWithBackgroundContext(t *testing.T) {
+	nc := newTestConn(t)
+	client := NewClient(nc, WithPrefix("test"))
+	svc := NewService(client, []Binding{{
+		Instance: "inst",
+		Name:     "f",
+		Handler: func(ctx context.Context, params []byte) ([]byte, error) {
+			return []byte("ok"), nil
+		},
+	}})
+
+	// context.Background() has no deadline; Start must not require one.
+	if err := svc.Start(context.Background()); err != nil {
+		t.Fatalf("Start with a no-deadline context: %s", err)
+	}
+	select {
+	case <-svc.Ready():
+	default:
+		t.Fatal("expected Ready to be closed once Start completes")
+	}
+
+	result, err := client.Invoke(context.Background(), "inst", "f", nil)
+	if err != nil {
+		t.Fatalf("Invoke: %s", err)
+	}
+	if string(result) != "ok" {
+		t.Fatalf("expected %q, got %q", "ok", result)
+	}
+
+	waitDone := make(chan error, 1)
+	go func() { waitDone <- svc.Wait() }()
+
+	if err := svc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %s", err)
+	}
+
+	select {
+	case err := <-waitDone:
+		if err != nil {
+			t.Fatalf("Wait: %s", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after Stop completed")
+	}
+}
+
+func TestServiceStopBeforeStartReturnsError(t *testing.T) {
+	client := NewClient(newTestConn(t))
+	svc := NewService(client, nil)
+
+	if err := svc.Stop(context.Background()); err == nil {
+		t.Fatal("expected an error stopping a Service that was never started")
+	}
+}
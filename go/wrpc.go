@@ -0,0 +1,60 @@
+// Package wrpc provides the transport-independent types shared by generated
+// wRPC client and server bindings.
+package wrpc
+
+import "context"
+
+// Invoker issues outgoing wRPC invocations for a given instance and function
+// name, writing the encoded parameters and returning the encoded results.
+// Transports (e.g. `wrpc.io/go/nats`) implement Invoker; generated bindings
+// call it, they never talk to a transport directly.
+type Invoker interface {
+	Invoke(ctx context.Context, instance, name string, params []byte) ([]byte, error)
+}
+
+// Client is an Invoker bound to a specific transport connection. Generated
+// bindings accept a Client to perform invocations and, for worlds that
+// export interfaces, to serve them.
+type Client interface {
+	Invoker
+}
+
+// Service is the lifecycle surface generated `NewService` constructors
+// (e.g. `sync_server.NewService`) return for a served world, replacing the
+// ad-hoc `(stop func() error, err error)` historically returned by
+// generated `Serve` functions. It lets callers start, stop, and supervise
+// several served worlds as part of one process, surfacing transport errors
+// instead of silently losing messages.
+type Service interface {
+	// Start performs the world's subscriptions.
+	Start(ctx context.Context) error
+	// Stop stops accepting new invocations and drains outstanding ones,
+	// as documented by the transport's Service implementation.
+	Stop(ctx context.Context) error
+	// Wait blocks until a subscription fails terminally, returning that
+	// error, or until Stop completes, returning nil.
+	Wait() error
+	// Ready closes once every subject named by the world is subscribed
+	// and flushed, suitable for wiring into a health probe.
+	Ready() <-chan struct{}
+	// Err returns the terminal subscription error observed by Wait, if
+	// any has occurred yet, without blocking.
+	Err() error
+}
+
+// Result is the Go representation of a WIT `result<ok, err>`. Exactly one of
+// Ok or Err is non-nil.
+type Result[T, E any] struct {
+	Ok  *T
+	Err *E
+}
+
+// Ok constructs a successful Result.
+func Ok[E, T any](v T) *Result[T, E] {
+	return &Result[T, E]{Ok: &v}
+}
+
+// Err constructs a failed Result.
+func Err[T, E any](v E) *Result[T, E] {
+	return &Result[T, E]{Err: &v}
+}